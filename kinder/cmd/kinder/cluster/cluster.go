@@ -0,0 +1,39 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster implements `kinder cluster`, grouping actions that act on
+// an already-created cluster as a whole (as opposed to `kinder create
+// cluster`, which provisions one).
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/cmd/kinder/cluster/start"
+	"k8s.io/kubeadm/kinder/cmd/kinder/cluster/stop"
+)
+
+// NewCommand returns a new cobra.Command for cluster lifecycle actions
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "cluster",
+		Short: "Actions on kinder clusters",
+	}
+	cmd.AddCommand(start.NewCommand())
+	cmd.AddCommand(stop.NewCommand())
+	return cmd
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package cluster
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	kinderconfig "k8s.io/kubeadm/kinder/pkg/cluster/config"
 	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
 	"k8s.io/kubeadm/kinder/pkg/constants"
 	kindAPI "sigs.k8s.io/kind/pkg/cluster/config"
@@ -36,6 +38,12 @@ const (
 	kubeDNSFLagName              = "kube-dns"
 	externalEtcdFlagName         = "external-etcd"
 	externalLoadBalancerFlagName = "external-load-balancer"
+	kubernetesVersionFlagName    = "kubernetes-version"
+	controlPlaneVersionFlagName  = "control-plane-version"
+	workerVersionFlagName        = "worker-version"
+	imageArchiveFlagName         = "image-archive"
+	registryMirrorFlagName       = "registry-mirror"
+	withRegistryFlagName         = "with-registry"
 )
 
 type flagpole struct {
@@ -47,6 +55,12 @@ type flagpole struct {
 	Retain               bool
 	ExternalEtcd         bool
 	ExternalLoadBalancer bool
+	KubernetesVersion    string
+	ControlPlaneVersion  string
+	WorkerVersion        string
+	ImageArchives        []string
+	RegistryMirrors      []string
+	WithRegistry         bool
 }
 
 // NewCommand returns a new cobra.Command for cluster creation
@@ -101,6 +115,36 @@ func NewCommand() *cobra.Command {
 		externalLoadBalancerFlagName, false,
 		"add an external load balancer to the cluster (implicit if number of control-plane nodes>1)",
 	)
+	cmd.Flags().StringVar(
+		&flags.KubernetesVersion,
+		kubernetesVersionFlagName, "",
+		"kubeadm/kubelet/kubectl version to install on every node, overriding whatever the node image ships with",
+	)
+	cmd.Flags().StringVar(
+		&flags.ControlPlaneVersion,
+		controlPlaneVersionFlagName, "",
+		fmt.Sprintf("kubeadm/kubelet/kubectl version to install on control-plane nodes, overriding --%s", kubernetesVersionFlagName),
+	)
+	cmd.Flags().StringVar(
+		&flags.WorkerVersion,
+		workerVersionFlagName, "",
+		fmt.Sprintf("kubeadm/kubelet/kubectl version to install on worker nodes, overriding --%s", kubernetesVersionFlagName),
+	)
+	cmd.Flags().StringArrayVar(
+		&flags.ImageArchives,
+		imageArchiveFlagName, nil,
+		"a `docker save` tarball path or image reference to preload into every node before kubeadm init/join; can be repeated",
+	)
+	cmd.Flags().StringArrayVar(
+		&flags.RegistryMirrors,
+		registryMirrorFlagName, nil,
+		"a <name>=<url> containerd registry mirror to configure on every node; can be repeated",
+	)
+	cmd.Flags().BoolVar(
+		&flags.WithRegistry,
+		withRegistryFlagName, false,
+		"start (or connect to) a local docker registry and record its endpoint in the kube-public local-registry-hosting ConfigMap",
+	)
 
 	return cmd
 }
@@ -112,8 +156,11 @@ func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed(configFlagName) && (cmd.Flags().Changed(controlPlaneNodesFlagName) ||
 		cmd.Flags().Changed(workerNodesFLagName) ||
 		cmd.Flags().Changed(externalEtcdFlagName) ||
-		cmd.Flags().Changed(externalLoadBalancerFlagName)) {
-		return errors.Errorf("flag --%s can't be used in combination with --%s flags", configFlagName, strings.Join([]string{controlPlaneNodesFlagName, workerNodesFLagName, externalEtcdFlagName, externalLoadBalancerFlagName}, ","))
+		cmd.Flags().Changed(externalLoadBalancerFlagName) ||
+		cmd.Flags().Changed(kubernetesVersionFlagName) ||
+		cmd.Flags().Changed(controlPlaneVersionFlagName) ||
+		cmd.Flags().Changed(workerVersionFlagName)) {
+		return errors.Errorf("flag --%s can't be used in combination with --%s flags", configFlagName, strings.Join([]string{controlPlaneNodesFlagName, workerNodesFLagName, externalEtcdFlagName, externalLoadBalancerFlagName, kubernetesVersionFlagName, controlPlaneVersionFlagName, workerVersionFlagName}, ","))
 	}
 
 	if flags.ControlPlanes < 0 || flags.Workers < 0 {
@@ -126,29 +173,101 @@ func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "error initializing the cluster cfg")
 	}
 
-	// override the config with the one from file, if specified
+	opts := []manager.Option{
+		manager.Retain(flags.Retain),
+		manager.ExternalLoadBalancer(flags.ExternalLoadBalancer),
+		manager.ExternalEtcd(flags.ExternalEtcd),
+		manager.NodeVersions(nodeVersions(flags.ControlPlanes, flags.Workers, flags.KubernetesVersion, flags.ControlPlaneVersion, flags.WorkerVersion)),
+		manager.PreloadImages(flags.ImageArchives),
+	}
+
+	// override the config with the one from file, if specified; the file can
+	// either be a plain kind config, or a kinder.k8s.io/v1alpha1 config
+	// carrying the richer, kinder-specific options above
 	if flags.Config != "" {
-		// load the config
-		cfg, err = kindencoding.Load(flags.Config)
+		apiVersion, kind, err := kinderconfig.DetectAPIVersion(flags.Config)
 		if err != nil {
-			return errors.Wrap(err, "error loading config")
+			return errors.Wrap(err, "error reading config")
+		}
+
+		if apiVersion == kinderconfig.APIVersion {
+			if kind != kinderconfig.Kind {
+				return errors.Errorf("unsupported kind %q for apiVersion %q", kind, apiVersion)
+			}
+
+			kinderCfg, err := kinderconfig.Load(flags.Config)
+			if err != nil {
+				return errors.Wrap(err, "error loading config")
+			}
+
+			cfg, opts, err = kinderCfg.ToKindConfig()
+			if err != nil {
+				return errors.Wrap(err, "error converting config")
+			}
+			opts = append(opts, manager.Retain(flags.Retain))
+			if len(flags.ImageArchives) > 0 {
+				opts = append(opts, manager.PreloadImages(append(kinderCfg.PreloadImages, flags.ImageArchives...)))
+			}
+		} else {
+			cfg, err = kindencoding.Load(flags.Config)
+			if err != nil {
+				return errors.Wrap(err, "error loading config")
+			}
+			opts = []manager.Option{manager.Retain(flags.Retain), manager.PreloadImages(flags.ImageArchives)}
 		}
 	}
 
+	registryMirrors, err := parseRegistryMirrors(flags.RegistryMirrors)
+	if err != nil {
+		return errors.Wrap(err, "error parsing registry mirrors")
+	}
+	opts = append(opts, manager.WithRegistry(flags.WithRegistry), manager.RegistryMirrors(registryMirrors))
+
 	// get a kinder cluster manager
-	if err = manager.CreateCluster(
-		flags.Name,
-		cfg,
-		manager.ExternalLoadBalancer(flags.ExternalLoadBalancer),
-		manager.ExternalEtcd(flags.ExternalEtcd),
-		manager.Retain(flags.Retain),
-	); err != nil {
+	if err = manager.CreateCluster(flags.Name, cfg, opts...); err != nil {
 		return errors.Wrap(err, "failed to create cluster")
 	}
 
 	return nil
 }
 
+// parseRegistryMirrors parses a list of "name=url" --registry-mirror flag
+// values into a name->url map.
+func parseRegistryMirrors(mirrors []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(mirrors))
+	for _, m := range mirrors {
+		parts := strings.SplitN(m, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("invalid --%s value %q, expected <name>=<url>", registryMirrorFlagName, m)
+		}
+		parsed[parts[0]] = parts[1]
+	}
+	return parsed, nil
+}
+
+// nodeVersions returns, for each node NewConfig will create (control-plane
+// nodes first, then workers, in that order), the kubeadm/kubelet/kubectl
+// version that should be installed on it before kubeadm init/join runs. A
+// per-role override takes precedence over the global --kubernetes-version;
+// an empty string means "use whatever the node image ships with".
+func nodeVersions(controlPlanes, workers int, kubernetesVersion, controlPlaneVersion, workerVersion string) []string {
+	if controlPlaneVersion == "" {
+		controlPlaneVersion = kubernetesVersion
+	}
+	if workerVersion == "" {
+		workerVersion = kubernetesVersion
+	}
+
+	versions := make([]string, 0, controlPlanes+workers)
+	for i := 0; i < controlPlanes; i++ {
+		versions = append(versions, controlPlaneVersion)
+	}
+	for i := 0; i < workers; i++ {
+		versions = append(versions, workerVersion)
+	}
+	return versions
+}
+
 // NewConfig returns the default config according to requested number of control-plane and worker nodes
 func NewConfig(controlPlanes, workers int, image string) (*kindAPI.Cluster, error) {
 	var latestPublicConfig = &kindAPIv1alpha3.Cluster{
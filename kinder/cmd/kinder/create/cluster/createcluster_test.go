@@ -0,0 +1,169 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNodeVersions(t *testing.T) {
+	cases := []struct {
+		name                                                   string
+		controlPlanes, workers                                 int
+		kubernetesVersion, controlPlaneVersion, workerVersion  string
+		want                                                   []string
+	}{
+		{
+			name:          "no versions requested",
+			controlPlanes: 1,
+			workers:       2,
+			want:          []string{"", "", ""},
+		},
+		{
+			name:              "global version applies to every node",
+			controlPlanes:     1,
+			workers:           2,
+			kubernetesVersion: "v1.17.0",
+			want:              []string{"v1.17.0", "v1.17.0", "v1.17.0"},
+		},
+		{
+			name:                "per-role overrides take precedence over the global version",
+			controlPlanes:       1,
+			workers:             2,
+			kubernetesVersion:   "v1.17.0",
+			controlPlaneVersion: "v1.18.0",
+			workerVersion:       "v1.16.0",
+			want:                []string{"v1.18.0", "v1.16.0", "v1.16.0"},
+		},
+		{
+			name:          "no nodes",
+			controlPlanes: 0,
+			workers:       0,
+			want:          []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := nodeVersions(c.controlPlanes, c.workers, c.kubernetesVersion, c.controlPlaneVersion, c.workerVersion)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("nodeVersions(%d, %d, %q, %q, %q) = %v, want %v",
+					c.controlPlanes, c.workers, c.kubernetesVersion, c.controlPlaneVersion, c.workerVersion, got, c.want)
+			}
+		})
+	}
+}
+
+func TestConfigFlagRejectsVersionFlags(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "kubernetes-version",
+			args: []string{"--config=/does/not/exist.yaml", "--kubernetes-version=v1.18.0"},
+		},
+		{
+			name: "control-plane-version",
+			args: []string{"--config=/does/not/exist.yaml", "--control-plane-version=v1.18.0"},
+		},
+		{
+			name: "worker-version",
+			args: []string{"--config=/does/not/exist.yaml", "--worker-version=v1.18.0"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmd := NewCommand()
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			cmd.SetArgs(c.args)
+
+			err := cmd.Execute()
+			if err == nil {
+				t.Fatalf("Execute(%v) = nil error, want error rejecting --config with a version flag", c.args)
+			}
+			if !strings.Contains(err.Error(), configFlagName) {
+				t.Errorf("Execute(%v) error = %q, want it to mention --%s", c.args, err.Error(), configFlagName)
+			}
+		})
+	}
+}
+
+func TestParseRegistryMirrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		mirrors []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			mirrors: nil,
+			want:    map[string]string{},
+		},
+		{
+			name:    "single mirror",
+			mirrors: []string{"docker.io=https://mirror.example.com"},
+			want:    map[string]string{"docker.io": "https://mirror.example.com"},
+		},
+		{
+			name:    "multiple mirrors",
+			mirrors: []string{"docker.io=https://mirror1.example.com", "gcr.io=https://mirror2.example.com"},
+			want: map[string]string{
+				"docker.io": "https://mirror1.example.com",
+				"gcr.io":    "https://mirror2.example.com",
+			},
+		},
+		{
+			name:    "missing equals sign",
+			mirrors: []string{"docker.io"},
+			wantErr: true,
+		},
+		{
+			name:    "empty name",
+			mirrors: []string{"=https://mirror.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "empty url",
+			mirrors: []string{"docker.io="},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseRegistryMirrors(c.mirrors)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("parseRegistryMirrors(%v) = nil error, want error", c.mirrors)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRegistryMirrors(%v) returned unexpected error: %v", c.mirrors, err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("parseRegistryMirrors(%v) = %v, want %v", c.mirrors, got, c.want)
+			}
+		})
+	}
+}
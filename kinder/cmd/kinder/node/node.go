@@ -0,0 +1,38 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package node implements `kinder node`, grouping actions that act on one or
+// more individual nodes of an already-created cluster.
+package node
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/cmd/kinder/node/start"
+	"k8s.io/kubeadm/kinder/cmd/kinder/node/stop"
+)
+
+// NewCommand returns a new cobra.Command for node-level actions
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "node",
+		Short: "Actions on kinder nodes",
+	}
+	cmd.AddCommand(start.NewCommand())
+	cmd.AddCommand(stop.NewCommand())
+	return cmd
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package start
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/nodes"
+)
+
+type flagpole struct {
+	Names []string
+}
+
+// NewCommand returns a new cobra.Command for starting one or more previously stopped nodes of a cluster
+func NewCommand() *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Use:   "start [node-name...]",
+		Short: "Starts one or more stopped nodes of a local Kubernetes cluster",
+		Long:  "Resumes the given node containers previously stopped with `kinder node stop`",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Names = args
+			return runE(flags, cmd, args)
+		},
+	}
+
+	return cmd
+}
+
+func runE(flags *flagpole, cmd *cobra.Command, args []string) error {
+	nodeList := make([]nodes.Node, 0, len(flags.Names))
+	for _, name := range flags.Names {
+		nodeList = append(nodeList, nodes.Node{Name: name})
+	}
+
+	if err := nodes.StartNodes(nodeList...); err != nil {
+		return errors.Wrap(err, "failed to start nodes")
+	}
+	return nil
+}
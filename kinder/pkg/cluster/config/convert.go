@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	kindAPI "sigs.k8s.io/kind/pkg/cluster/config"
+	kindencoding "sigs.k8s.io/kind/pkg/cluster/config/encoding"
+	kindAPIv1alpha3 "sigs.k8s.io/kind/pkg/cluster/config/v1alpha3"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/manager"
+)
+
+// ToKindConfig converts this kinder Cluster, via a defaulted kind v1alpha3
+// Cluster, into the internal kind Cluster manager.CreateCluster expects, plus the manager.Option values carrying the
+// kinder-specific behaviours (external etcd/load-balancer, per-node
+// versions, preloaded images, staged kubeadm phases) kind's own config
+// doesn't know about.
+func (c *Cluster) ToKindConfig() (*kindAPI.Cluster, []manager.Option, error) {
+	latestPublicConfig := &kindAPIv1alpha3.Cluster{
+		Nodes: []kindAPIv1alpha3.Node{},
+	}
+
+	versions := make([]string, 0, len(c.Nodes))
+	configPatches := make([][]manager.ConfigPatch, 0, len(c.Nodes))
+	for _, n := range c.Nodes {
+		latestPublicConfig.Nodes = append(latestPublicConfig.Nodes, kindAPIv1alpha3.Node{
+			Role:  kindAPIv1alpha3.NodeRole(n.Role),
+			Image: n.Image,
+		})
+		versions = append(versions, n.KubernetesVersion)
+
+		patches := make([]manager.ConfigPatch, 0, len(n.KubeadmConfigPatches))
+		for _, p := range n.KubeadmConfigPatches {
+			patches = append(patches, manager.ConfigPatch{Target: p.Target, Patch: p.Patch})
+		}
+		configPatches = append(configPatches, patches)
+	}
+
+	kindencoding.Scheme.Default(latestPublicConfig)
+
+	cfg := &kindAPI.Cluster{}
+	kindencoding.Scheme.Convert(latestPublicConfig, cfg, nil)
+
+	phases := make([]manager.Phase, 0, len(c.Phases))
+	for _, p := range c.Phases {
+		phases = append(phases, manager.Phase{Name: p.Name, KubeadmFlags: p.KubeadmFlags})
+	}
+
+	opts := []manager.Option{
+		manager.ExternalEtcd(c.ExternalEtcd),
+		manager.ExternalLoadBalancer(c.ExternalLoadBalancer),
+		manager.NodeVersions(versions),
+		manager.NodeConfigPatches(configPatches),
+		manager.PreloadImages(c.PreloadImages),
+		manager.Phases(phases),
+	}
+
+	return cfg, opts, nil
+}
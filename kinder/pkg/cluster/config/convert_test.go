@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "testing"
+
+func TestToKindConfig(t *testing.T) {
+	c := &Cluster{
+		Nodes: []Node{
+			{Role: "control-plane", Image: "image:v1", KubernetesVersion: "v1.17.0"},
+			{Role: "worker", Image: "image:v1"},
+		},
+		ExternalEtcd:         true,
+		ExternalLoadBalancer: true,
+		PreloadImages:        []string{"busybox"},
+		Phases: []Phase{
+			{Name: "init", KubeadmFlags: []string{"--v=5"}},
+		},
+	}
+
+	cfg, opts, err := c.ToKindConfig()
+	if err != nil {
+		t.Fatalf("ToKindConfig() returned unexpected error: %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("ToKindConfig() returned a nil *kindAPI.Cluster")
+	}
+	if len(cfg.Nodes) != len(c.Nodes) {
+		t.Errorf("ToKindConfig() produced %d nodes, want %d", len(cfg.Nodes), len(c.Nodes))
+	}
+	if len(opts) == 0 {
+		t.Error("ToKindConfig() returned no manager.Option values, want one per kinder-specific behaviour")
+	}
+}
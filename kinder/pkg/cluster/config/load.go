@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+// DetectAPIVersion reads just the apiVersion/kind fields out of the config
+// file at path, without decoding the rest of it, so callers can pick the
+// right decoder for the full file (this package's, or the kind one).
+func DetectAPIVersion(path string) (apiVersion, kind string, err error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to read config file %q", path)
+	}
+
+	var meta TypeMeta
+	if err := yaml.Unmarshal(contents, &meta); err != nil {
+		return "", "", errors.Wrapf(err, "failed to unmarshal config file %q", path)
+	}
+
+	return meta.APIVersion, meta.Kind, nil
+}
+
+// Load reads and decodes a kinder.k8s.io/v1alpha1 Cluster from path.
+func Load(path string) (*Cluster, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read config file %q", path)
+	}
+
+	cluster := &Cluster{}
+	if err := yaml.Unmarshal(contents, cluster); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal config file %q", path)
+	}
+
+	if cluster.APIVersion != APIVersion {
+		return nil, errors.Errorf("unsupported apiVersion %q, expected %q", cluster.APIVersion, APIVersion)
+	}
+	if cluster.Kind != Kind {
+		return nil, errors.Errorf("unsupported kind %q, expected %q", cluster.Kind, Kind)
+	}
+	if len(cluster.Nodes) == 0 {
+		return nil, errors.New("config must define at least one node")
+	}
+	for _, n := range cluster.Nodes {
+		if n.Role != constants.ControlPlaneNodeRoleValue && n.Role != constants.WorkerNodeRoleValue {
+			return nil, errors.Errorf("invalid node role %q, must be %q or %q", n.Role, constants.ControlPlaneNodeRoleValue, constants.WorkerNodeRoleValue)
+		}
+	}
+
+	return cluster, nil
+}
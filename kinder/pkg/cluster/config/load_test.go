@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempConfig(t *testing.T, contents string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "kinder-config-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+	return path
+}
+
+func TestDetectAPIVersion(t *testing.T) {
+	cases := []struct {
+		name           string
+		contents       string
+		wantAPIVersion string
+		wantKind       string
+	}{
+		{
+			name: "kinder config",
+			contents: `apiVersion: kinder.k8s.io/v1alpha1
+kind: Cluster
+nodes:
+- role: control-plane
+`,
+			wantAPIVersion: "kinder.k8s.io/v1alpha1",
+			wantKind:       "Cluster",
+		},
+		{
+			name: "kind config",
+			contents: `apiVersion: kind.sigs.k8s.io/v1alpha3
+kind: Cluster
+`,
+			wantAPIVersion: "kind.sigs.k8s.io/v1alpha3",
+			wantKind:       "Cluster",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := writeTempConfig(t, c.contents)
+			apiVersion, kind, err := DetectAPIVersion(path)
+			if err != nil {
+				t.Fatalf("DetectAPIVersion(%q) returned unexpected error: %v", path, err)
+			}
+			if apiVersion != c.wantAPIVersion || kind != c.wantKind {
+				t.Errorf("DetectAPIVersion(%q) = (%q, %q), want (%q, %q)", path, apiVersion, kind, c.wantAPIVersion, c.wantKind)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		wantErr  bool
+	}{
+		{
+			name: "valid config",
+			contents: `apiVersion: kinder.k8s.io/v1alpha1
+kind: Cluster
+nodes:
+- role: control-plane
+- role: worker
+`,
+		},
+		{
+			name: "no nodes",
+			contents: `apiVersion: kinder.k8s.io/v1alpha1
+kind: Cluster
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid node role",
+			contents: `apiVersion: kinder.k8s.io/v1alpha1
+kind: Cluster
+nodes:
+- role: external-etcd
+`,
+			wantErr: true,
+		},
+		{
+			name: "wrong apiVersion",
+			contents: `apiVersion: kind.sigs.k8s.io/v1alpha3
+kind: Cluster
+nodes:
+- role: control-plane
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := writeTempConfig(t, c.contents)
+			_, err := Load(path)
+			if c.wantErr && err == nil {
+				t.Fatalf("Load(%q) = nil error, want error", path)
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("Load(%q) returned unexpected error: %v", path, err)
+			}
+		})
+	}
+}
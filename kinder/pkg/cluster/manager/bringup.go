@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/nodes"
+	"k8s.io/kubeadm/kinder/pkg/container/docker"
+)
+
+// bringUpKubeadm drives kubeadm inside nodeList to form the cluster: the
+// default init-then-join flow, or, if phases is non-empty, each kubeadm
+// action in order with its own KubeadmFlags instead.
+func bringUpKubeadm(nodeList []nodes.Node, phases []Phase) error {
+	if len(phases) > 0 {
+		return runPhases(nodeList, phases)
+	}
+
+	cp, err := controlPlaneNode(nodeList)
+	if err != nil {
+		return err
+	}
+
+	if err := docker.Exec(cp.Name, "kubeadm", "init", "--patches", kubeadmPatchesDir); err != nil {
+		return errors.Wrapf(err, "failed to init control-plane on node %q", cp.Name)
+	}
+
+	joinCmd, err := docker.Output(cp.Name, "kubeadm", "token", "create", "--print-join-command")
+	if err != nil {
+		return errors.Wrap(err, "failed to compute the kubeadm join command")
+	}
+	joinCmd = strings.TrimSpace(joinCmd) + " --patches " + kubeadmPatchesDir
+
+	for _, n := range nodeList {
+		if n.Name == cp.Name {
+			continue
+		}
+		if err := docker.Exec(n.Name, "sh", "-c", joinCmd); err != nil {
+			return errors.Wrapf(err, "failed to join node %q to the cluster", n.Name)
+		}
+	}
+
+	return nil
+}
+
+// runPhases drives each kubeadm action in phases, in order, with its own
+// KubeadmFlags, on the node(s) that action actually applies to: "join" runs
+// on every node other than the first control-plane node (there is nothing
+// to join on that node itself), everything else (init, upgrade, reset, ...)
+// runs on the first control-plane node.
+func runPhases(nodeList []nodes.Node, phases []Phase) error {
+	cp, err := controlPlaneNode(nodeList)
+	if err != nil {
+		return err
+	}
+
+	for _, phase := range phases {
+		targets := phaseTargets(phase.Name, cp, nodeList)
+		args := append([]string{"kubeadm", phase.Name}, phase.KubeadmFlags...)
+		if phase.Name == "init" || phase.Name == "join" {
+			args = append(args, "--patches", kubeadmPatchesDir)
+		}
+
+		for _, n := range targets {
+			if err := docker.Exec(n.Name, args...); err != nil {
+				return errors.Wrapf(err, "failed to run kubeadm %s on node %q", phase.Name, n.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// phaseTargets returns the nodes a kubeadm phase named phaseName should run
+// on: every node other than cp for "join" (including any further
+// control-plane nodes), cp for everything else.
+func phaseTargets(phaseName string, cp nodes.Node, nodeList []nodes.Node) []nodes.Node {
+	if phaseName != "join" {
+		return []nodes.Node{cp}
+	}
+
+	targets := make([]nodes.Node, 0, len(nodeList)-1)
+	for _, n := range nodeList {
+		if n.Name != cp.Name {
+			targets = append(targets, n)
+		}
+	}
+	return targets
+}
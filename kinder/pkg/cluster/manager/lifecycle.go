@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/nodes"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/container/docker"
+)
+
+// controlPlaneEndpointWaitTimeout bounds how long StartCluster waits for
+// kube-apiserver to answer after resuming a stopped cluster.
+const controlPlaneEndpointWaitTimeout = 2 * time.Minute
+
+// StopCluster stops every container belonging to the named cluster with a
+// single batched `docker stop`, leaving the containers (and their disk
+// state) in place so the cluster can be resumed later with StartCluster.
+func StopCluster(name string) error {
+	nodeList, err := nodes.List(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list nodes for cluster %q", name)
+	}
+	if len(nodeList) == 0 {
+		return errors.Errorf("no nodes found for cluster %q", name)
+	}
+
+	if err := nodes.StopNodes(nodeList...); err != nil {
+		return errors.Wrapf(err, "failed to stop cluster %q", name)
+	}
+	return nil
+}
+
+// StartCluster resumes every container belonging to the named cluster with
+// a single batched `docker start`, then re-runs the minimum bring-up steps
+// required for kubelet/etcd to rejoin the control-plane: waiting for
+// kube-apiserver to answer on the load balancer (or the single control-plane
+// node, if there is no load balancer), and re-issuing
+// `kubeadm init phase upload-config` on a control-plane node in case the
+// certificates were rotated while the cluster was stopped.
+func StartCluster(name string) error {
+	nodeList, err := nodes.List(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list nodes for cluster %q", name)
+	}
+	if len(nodeList) == 0 {
+		return errors.Errorf("no nodes found for cluster %q", name)
+	}
+
+	if err := nodes.StartNodes(nodeList...); err != nil {
+		return errors.Wrapf(err, "failed to start cluster %q", name)
+	}
+
+	if err := waitForControlPlane(nodeList); err != nil {
+		return errors.Wrapf(err, "failed to bring cluster %q back up", name)
+	}
+
+	if err := reuploadConfig(nodeList); err != nil {
+		return errors.Wrapf(err, "failed to bring cluster %q back up", name)
+	}
+
+	return nil
+}
+
+// controlPlaneEndpointNode returns the node kube-apiserver should be
+// reachable on: the external load balancer if the cluster has one, otherwise
+// the (single) control-plane node.
+func controlPlaneEndpointNode(nodeList []nodes.Node) (nodes.Node, error) {
+	for _, n := range nodeList {
+		if n.Role == constants.ExternalLoadBalancerNodeRoleValue {
+			return n, nil
+		}
+	}
+	return controlPlaneNode(nodeList)
+}
+
+// waitForControlPlane polls kube-apiserver's /healthz until it answers on
+// the load balancer node (or the single control-plane node, if the cluster
+// has no load balancer), or controlPlaneEndpointWaitTimeout elapses.
+func waitForControlPlane(nodeList []nodes.Node) error {
+	endpoint, err := controlPlaneEndpointNode(nodeList)
+	if err != nil {
+		return err
+	}
+
+	// any running node can resolve the endpoint's name over the docker
+	// network's embedded DNS, so probe from the endpoint node itself
+	url := fmt.Sprintf("https://%s:6443/healthz", endpoint.Name)
+
+	deadline := time.Now().Add(controlPlaneEndpointWaitTimeout)
+	for {
+		if err := docker.Exec(endpoint.Name, "curl", "-sk", "--max-time", "2", url); err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("kube-apiserver did not become healthy on node %q within %s", endpoint.Name, controlPlaneEndpointWaitTimeout)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// reuploadConfig re-issues `kubeadm init phase upload-config all` on a
+// control-plane node. It runs unconditionally after a resume: the phase is
+// idempotent, and kinder has no reliable signal for whether certificates
+// were rotated while the containers were stopped.
+func reuploadConfig(nodeList []nodes.Node) error {
+	cp, err := controlPlaneNode(nodeList)
+	if err != nil {
+		return err
+	}
+	if err := docker.Exec(cp.Name, "kubeadm", "init", "phase", "upload-config", "all"); err != nil {
+		return errors.Wrapf(err, "failed to re-upload kubeadm config on node %q", cp.Name)
+	}
+	return nil
+}
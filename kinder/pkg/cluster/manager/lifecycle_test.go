@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"testing"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/nodes"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+)
+
+func TestControlPlaneEndpointNode(t *testing.T) {
+	cp := nodes.Node{Name: "kind-control-plane", Role: constants.ControlPlaneNodeRoleValue}
+	worker := nodes.Node{Name: "kind-worker", Role: constants.WorkerNodeRoleValue}
+	lb := nodes.Node{Name: "kind-external-load-balancer", Role: constants.ExternalLoadBalancerNodeRoleValue}
+
+	cases := []struct {
+		name     string
+		nodeList []nodes.Node
+		want     nodes.Node
+		wantErr  bool
+	}{
+		{
+			name:     "no load balancer returns the control-plane node",
+			nodeList: []nodes.Node{cp, worker},
+			want:     cp,
+		},
+		{
+			name:     "load balancer present takes precedence over the control-plane node",
+			nodeList: []nodes.Node{cp, worker, lb},
+			want:     lb,
+		},
+		{
+			name:     "no control-plane or load balancer node errors",
+			nodeList: []nodes.Node{worker},
+			wantErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := controlPlaneEndpointNode(c.nodeList)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("controlPlaneEndpointNode(%v) = nil error, want error", c.nodeList)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("controlPlaneEndpointNode(%v) returned unexpected error: %v", c.nodeList, err)
+			}
+			if got != c.want {
+				t.Errorf("controlPlaneEndpointNode(%v) = %v, want %v", c.nodeList, got, c.want)
+			}
+		})
+	}
+}
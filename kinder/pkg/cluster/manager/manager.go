@@ -0,0 +1,215 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manager implements the kinder cluster lifecycle: provisioning the
+// docker containers backing a cluster's nodes and driving kubeadm inside
+// them to bring the control-plane and etcd up.
+package manager
+
+import (
+	"github.com/pkg/errors"
+
+	kindAPI "sigs.k8s.io/kind/pkg/cluster/config"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/nodes"
+	"k8s.io/kubeadm/kinder/pkg/container/docker"
+)
+
+// options holds the settings collected from the Option funcs passed to
+// CreateCluster.
+type options struct {
+	externalLoadBalancer bool
+	externalEtcd         bool
+	retain               bool
+	nodeVersions         []string
+	nodeConfigPatches    [][]ConfigPatch
+	preloadImages        []string
+	phases               []Phase
+	withRegistry         bool
+	registryMirrors      map[string]string
+}
+
+// ConfigPatch is a single strategic merge patch to apply to a node's kubeadm
+// config at init/join time. Target must be one of the component names
+// kubeadm's --patches flag recognizes by filename prefix (kube-apiserver,
+// kube-controller-manager, kube-scheduler, etcd, kubeletconfiguration);
+// kubeadm silently ignores any patch file whose name doesn't start with one
+// of those, so Target is required rather than left to whoever names the
+// file on disk.
+type ConfigPatch struct {
+	Target string
+	Patch  string
+}
+
+// Option is a functional option for CreateCluster.
+type Option func(*options)
+
+// ExternalLoadBalancer requests an external load balancer container in
+// front of the control-plane nodes. CreateCluster does not provision one:
+// it returns an error when this is set to true.
+func ExternalLoadBalancer(v bool) Option {
+	return func(o *options) {
+		o.externalLoadBalancer = v
+	}
+}
+
+// ExternalEtcd requests an external etcd container, used by kubeadm instead
+// of the default stacked etcd. CreateCluster does not provision one: it
+// returns an error when this is set to true.
+func ExternalEtcd(v bool) Option {
+	return func(o *options) {
+		o.externalEtcd = v
+	}
+}
+
+// Retain instructs CreateCluster to leave the cluster's containers running
+// when cluster creation fails, for debugging.
+func Retain(v bool) Option {
+	return func(o *options) {
+		o.retain = v
+	}
+}
+
+// NodeVersions instructs CreateCluster to install a specific
+// kubeadm/kubelet/kubectl version on each node before running kubeadm
+// init/join, instead of using whatever the node image ships with. versions
+// must have one entry per node in cfg.Nodes, in the same order; an empty
+// entry leaves that node's image-provided version untouched.
+func NodeVersions(versions []string) Option {
+	return func(o *options) {
+		o.nodeVersions = versions
+	}
+}
+
+// NodeConfigPatches instructs CreateCluster to apply the given strategic
+// merge patches to each node's kubeadm config at init/join time, via
+// `kubeadm ... --patches`. patches must have one entry per node in
+// cfg.Nodes, in the same order; a nil/empty entry leaves that node
+// unpatched.
+func NodeConfigPatches(patches [][]ConfigPatch) Option {
+	return func(o *options) {
+		o.nodeConfigPatches = patches
+	}
+}
+
+// WithRegistry instructs CreateCluster to start (or connect to) a local
+// docker registry container on the cluster's docker network, and record its
+// endpoint in the local-registry-hosting ConfigMap in kube-public.
+func WithRegistry(v bool) Option {
+	return func(o *options) {
+		o.withRegistry = v
+	}
+}
+
+// RegistryMirrors instructs CreateCluster to configure containerd on every
+// node with a registry.mirrors entry for each name->url pair, before
+// kubelet starts.
+func RegistryMirrors(mirrors map[string]string) Option {
+	return func(o *options) {
+		o.registryMirrors = mirrors
+	}
+}
+
+// CreateCluster provisions the docker containers described by cfg under the
+// given cluster name, then drives kubeadm inside them to bring up the
+// control-plane and join the workers.
+//
+// o.externalEtcd/o.externalLoadBalancer are rejected rather than silently
+// ignored: this package doesn't provision an external-etcd or external
+// load-balancer container yet, and a cluster created while pretending to
+// honour those flags would be a stacked-etcd, single-endpoint cluster
+// masquerading as something else.
+func CreateCluster(name string, cfg *kindAPI.Cluster, opts ...Option) (err error) {
+	o := &options{}
+	for _, apply := range opts {
+		apply(o)
+	}
+
+	if o.externalEtcd {
+		return errors.New("--external-etcd is not yet supported by kinder cluster create")
+	}
+	if o.externalLoadBalancer {
+		return errors.New("--external-load-balancer is not yet supported by kinder cluster create")
+	}
+
+	var nodeList []nodes.Node
+	var registryContainerName string
+	var registryCreated bool
+	defer func() {
+		if err != nil && !o.retain {
+			if rmErr := nodes.RemoveNodes(nodeList...); rmErr != nil {
+				err = errors.Wrapf(err, "(additionally failed to remove nodes for cleanup: %v)", rmErr)
+			}
+			if registryCreated {
+				if rmErr := docker.Remove(registryContainerName); rmErr != nil {
+					err = errors.Wrapf(err, "(additionally failed to remove registry container %q for cleanup: %v)", registryContainerName, rmErr)
+				}
+			}
+		}
+	}()
+
+	nodeList, err = provisionNodes(name, cfg, o.nodeVersions, o.nodeConfigPatches)
+	if err != nil {
+		return errors.Wrap(err, "failed to provision nodes")
+	}
+
+	if len(o.preloadImages) > 0 {
+		if err = preloadImages(nodeList, o.preloadImages); err != nil {
+			return errors.Wrap(err, "failed to preload images")
+		}
+	}
+
+	mirrors := o.registryMirrors
+	registryEndpoint := ""
+	if o.withRegistry {
+		var registryName string
+		registryName, registryEndpoint, registryCreated, err = ensureRegistry(name)
+		if err != nil {
+			return errors.Wrap(err, "failed to start local registry")
+		}
+		registryContainerName = registryName
+		if mirrors == nil {
+			mirrors = map[string]string{}
+		}
+		// the registry:2 container serves plain HTTP; containerd treats a
+		// scheme-less mirror endpoint as HTTPS, so the scheme must be
+		// explicit here (recordRegistryConfigMap's host: field below stays
+		// scheme-less, per the local-registry-hosting convention).
+		mirrors[registryEndpoint] = "http://" + registryEndpoint
+	}
+	if len(mirrors) > 0 {
+		if err = writeRegistryMirrors(nodeList, mirrors); err != nil {
+			return errors.Wrap(err, "failed to configure registry mirrors")
+		}
+	}
+
+	if err = bringUpKubeadm(nodeList, o.phases); err != nil {
+		return errors.Wrap(err, "failed to bring up the cluster")
+	}
+
+	if registryEndpoint != "" {
+		var cp nodes.Node
+		cp, err = controlPlaneNode(nodeList)
+		if err != nil {
+			return err
+		}
+		if err = recordRegistryConfigMap(cp.Name, registryEndpoint); err != nil {
+			return errors.Wrap(err, "failed to record the registry endpoint")
+		}
+	}
+
+	return nil
+}
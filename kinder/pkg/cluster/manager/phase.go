@@ -0,0 +1,44 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+// Phase is a single step of the ordered kubeadm action list CreateCluster
+// drives after the nodes are up, as described by a kinder config's
+// `phases:` list.
+type Phase struct {
+	// Name is the kubeadm action to perform: init, join, upgrade or reset.
+	Name string
+	// KubeadmFlags are extra command line flags passed to kubeadm for this phase.
+	KubeadmFlags []string
+}
+
+// Phases instructs CreateCluster to drive the given ordered list of kubeadm
+// actions instead of the default init+join flow.
+func Phases(phases []Phase) Option {
+	return func(o *options) {
+		o.phases = phases
+	}
+}
+
+// PreloadImages instructs CreateCluster to import the given docker image
+// references or `docker save` tarball paths into every node before running
+// kubeadm init/join.
+func PreloadImages(images []string) Option {
+	return func(o *options) {
+		o.preloadImages = images
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/nodes"
+	"k8s.io/kubeadm/kinder/pkg/container/docker"
+)
+
+// nodeImageArchiveDir is where preloadImages stages image tarballs inside
+// each node before importing them into containerd.
+const nodeImageArchiveDir = "/kind/images"
+
+// preloadImages imports every image archive or image reference in images
+// into every node, via `docker cp` followed by `ctr -n k8s.io images
+// import`, so kubeadm's preflight ImagePull check becomes a no-op for them.
+// It runs before kubeadm init/join.
+func preloadImages(nodeList []nodes.Node, images []string) error {
+	for i, image := range images {
+		archive, cleanup, err := toArchive(image)
+		if err != nil {
+			return errors.Wrapf(err, "failed to prepare image %q for preload", image)
+		}
+
+		destPath := fmt.Sprintf("%s/%d.tar", nodeImageArchiveDir, i)
+		for _, node := range nodeList {
+			if err := docker.Exec(node.Name, "mkdir", "-p", nodeImageArchiveDir); err != nil {
+				cleanup()
+				return errors.Wrapf(err, "failed to create image archive dir on node %q", node.Name)
+			}
+			if err := docker.CopyTo(node.Name, archive, destPath); err != nil {
+				cleanup()
+				return errors.Wrapf(err, "failed to copy image %q into node %q", image, node.Name)
+			}
+			if err := docker.Exec(node.Name, "ctr", "-n", "k8s.io", "images", "import", destPath); err != nil {
+				cleanup()
+				return errors.Wrapf(err, "failed to import image %q into node %q", image, node.Name)
+			}
+		}
+
+		cleanup()
+	}
+	return nil
+}
+
+// toArchive returns a local `docker save` tarball path for image: image
+// itself, if it already looks like a tarball on disk, otherwise a freshly
+// saved tarball for the image reference. The returned cleanup func removes
+// any tarball toArchive created; it is a no-op when image was already a
+// tarball.
+func toArchive(image string) (archive string, cleanup func(), err error) {
+	if _, statErr := os.Stat(image); statErr == nil {
+		return image, func() {}, nil
+	}
+
+	tmpDir, err := ioutil.TempDir("", "kinder-preload-image")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to create temp dir")
+	}
+	cleanup = func() { os.RemoveAll(tmpDir) }
+
+	archive = filepath.Join(tmpDir, "image.tar")
+	if err := docker.Save(image, archive); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return archive, cleanup, nil
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestToArchiveWithExistingTarball(t *testing.T) {
+	f, err := ioutil.TempFile("", "kinder-toarchive-test")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	f.Close()
+
+	archive, cleanup, err := toArchive(f.Name())
+	if err != nil {
+		t.Fatalf("toArchive(%q) returned unexpected error: %v", f.Name(), err)
+	}
+	if archive != f.Name() {
+		t.Errorf("toArchive(%q) = %q, want the path unchanged", f.Name(), archive)
+	}
+
+	// cleanup must be a no-op: it must not remove the caller's own file.
+	cleanup()
+	if _, err := os.Stat(f.Name()); err != nil {
+		t.Errorf("toArchive's cleanup removed the caller-supplied tarball: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kindAPI "sigs.k8s.io/kind/pkg/cluster/config"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/nodes"
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/container/docker"
+)
+
+// kubeadmPatchesDir is where provisionNodes stages each node's
+// kubeadm-config patches, and where bringUpKubeadm/runPhases point
+// kubeadm's --patches flag. It is created on every node regardless of
+// whether that node has any patches, so the flag can always be passed.
+const kubeadmPatchesDir = "/kind/patches"
+
+// provisionNodes creates one docker container per cfg.Nodes entry on the
+// kind docker network, labelled with constants.ClusterLabelKey/
+// NodeRoleLabelKey so the group can later be discovered with nodes.List,
+// installs the requested kubeadm/kubelet/kubectl version (if any) on each
+// one, and stages any requested kubeadm config patches under
+// kubeadmPatchesDir. versions and configPatches must each have one entry
+// per cfg.Nodes entry, in the same order; an empty versions entry leaves
+// that node's image-provided version alone, and a nil/empty configPatches
+// entry leaves that node unpatched.
+//
+// Any nodes successfully created before a failure are returned alongside
+// the error, so the caller can clean them up.
+func provisionNodes(clusterName string, cfg *kindAPI.Cluster, versions []string, configPatches [][]ConfigPatch) ([]nodes.Node, error) {
+	ordinals := map[string]int{}
+	nodeList := make([]nodes.Node, 0, len(cfg.Nodes))
+
+	for i, n := range cfg.Nodes {
+		role := string(n.Role)
+		ordinals[role]++
+		containerName := nodeName(clusterName, role, ordinals[role])
+
+		if err := docker.Run(containerName, n.Image,
+			"--label", fmt.Sprintf("%s=%s", constants.ClusterLabelKey, clusterName),
+			"--label", fmt.Sprintf("%s=%s", constants.NodeRoleLabelKey, role),
+			"--privileged",
+			"--network", registryNetworkName,
+		); err != nil {
+			return nodeList, errors.Wrapf(err, "failed to create node %q", containerName)
+		}
+
+		nodeList = append(nodeList, nodes.Node{Name: containerName, Role: role})
+
+		if i < len(versions) && versions[i] != "" {
+			if err := installKubernetesVersion(containerName, versions[i]); err != nil {
+				return nodeList, errors.Wrapf(err, "failed to install kubernetes version %q on node %q", versions[i], containerName)
+			}
+		}
+
+		var patches []ConfigPatch
+		if i < len(configPatches) {
+			patches = configPatches[i]
+		}
+		if err := writeKubeadmConfigPatches(containerName, patches); err != nil {
+			return nodeList, errors.Wrapf(err, "failed to stage kubeadm config patches on node %q", containerName)
+		}
+	}
+
+	return nodeList, nil
+}
+
+// writeKubeadmConfigPatches creates kubeadmPatchesDir on containerName and
+// writes each entry of patches as its own file inside it, named after its
+// Target, so kubeadm's --patches flag (which only picks up files whose name
+// starts with a recognized target) actually applies them whether or not
+// this node has any patches.
+func writeKubeadmConfigPatches(containerName string, patches []ConfigPatch) error {
+	if err := docker.Exec(containerName, "mkdir", "-p", kubeadmPatchesDir); err != nil {
+		return err
+	}
+	for i, patch := range patches {
+		destPath := fmt.Sprintf("%s/%s%d.yaml", kubeadmPatchesDir, patch.Target, i)
+		cmd := fmt.Sprintf("cat > %s <<'EOF'\n%s\nEOF", destPath, patch.Patch)
+		if err := docker.Exec(containerName, "sh", "-c", cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeName mirrors kind's own node naming: the first node of a role is
+// named "<cluster>-<role>", subsequent ones get a numeric suffix.
+func nodeName(clusterName, role string, ordinal int) string {
+	if ordinal == 1 {
+		return fmt.Sprintf("%s-%s", clusterName, role)
+	}
+	return fmt.Sprintf("%s-%s%d", clusterName, role, ordinal)
+}
+
+// installKubernetesVersion installs the given kubeadm/kubelet/kubectl
+// version inside containerName, replacing whatever the node image ships
+// with, via the node image's package manager. version is accepted in the
+// conventional "vX.Y.Z" form used everywhere else in kinder; Debian/Ubuntu
+// packages are versioned without the leading "v" (e.g. "1.17.0-00"), so it
+// is stripped before being handed to apt.
+func installKubernetesVersion(containerName, version string) error {
+	aptVersion := strings.TrimPrefix(version, "v")
+	cmd := fmt.Sprintf(
+		"apt-get update && apt-get install -y --allow-downgrades --allow-change-held-packages kubeadm=%s* kubelet=%s* kubectl=%s*",
+		aptVersion, aptVersion, aptVersion,
+	)
+	if err := docker.Exec(containerName, "sh", "-c", cmd); err != nil {
+		return err
+	}
+	return nil
+}
+
+// controlPlaneNode returns the first control-plane node in nodeList.
+func controlPlaneNode(nodeList []nodes.Node) (nodes.Node, error) {
+	for _, n := range nodeList {
+		if n.Role == constants.ControlPlaneNodeRoleValue {
+			return n, nil
+		}
+	}
+	return nodes.Node{}, errors.New("no control-plane node found")
+}
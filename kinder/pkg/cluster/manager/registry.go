@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/cluster/nodes"
+	"k8s.io/kubeadm/kinder/pkg/container/docker"
+)
+
+const (
+	// registryImage is the image used for the local registry started by
+	// ensureRegistry when --with-registry is set.
+	registryImage = "registry:2"
+
+	// registryNetworkName is the docker network the local registry is
+	// attached to, the same network kind/kinder puts cluster nodes on.
+	registryNetworkName = "kind"
+
+	// registryConfigMapName is the well-known ConfigMap downstream tests can
+	// read to discover the registry endpoint, per the kind local-registry
+	// convention.
+	registryConfigMapName = "local-registry-hosting"
+)
+
+// ensureRegistry starts (or reuses, or connects to the network) a local
+// docker registry container for clusterName on the kind docker network, so
+// cluster nodes can pull images from it without needing a route to the
+// public internet. created reports whether this call started a brand new
+// container, so the caller knows whether it's theirs to remove on failure.
+func ensureRegistry(clusterName string) (containerName, endpoint string, created bool, err error) {
+	containerName = fmt.Sprintf("%s-registry", clusterName)
+	endpoint = fmt.Sprintf("%s:5000", containerName)
+
+	exists, err := docker.Exists(containerName)
+	if err != nil {
+		return "", "", false, errors.Wrapf(err, "failed to check for registry container %q", containerName)
+	}
+	if exists {
+		// every registry container this function ever creates is already
+		// started on registryNetworkName, so reattaching here is only ever
+		// needed if something else disconnected it; ignore the "already
+		// exists" error docker returns for the (normal) case it's still
+		// attached.
+		if err := docker.Connect(registryNetworkName, containerName); err != nil && !isAlreadyConnected(err) {
+			return "", "", false, err
+		}
+		return containerName, endpoint, false, nil
+	}
+
+	// nodes reach the registry by container name over registryNetworkName, so
+	// no host port needs to be published; doing so would collide with any
+	// other --with-registry cluster's registry container on the same host.
+	if err := docker.Run(containerName, registryImage,
+		"--network", registryNetworkName,
+		"--restart=always",
+	); err != nil {
+		return "", "", false, errors.Wrapf(err, "failed to start registry container %q", containerName)
+	}
+
+	return containerName, endpoint, true, nil
+}
+
+// isAlreadyConnected reports whether err is the error docker returns for
+// `docker network connect` on a container that's already attached to the
+// network, which is expected rather than a real failure.
+func isAlreadyConnected(err error) bool {
+	return strings.Contains(err.Error(), "already exists")
+}
+
+// writeRegistryMirrors appends a containerd registry.mirrors entry for each
+// name=url pair in mirrors to /etc/containerd/config.toml on every node,
+// then restarts containerd on that node so the mirror takes effect.
+// containerd is already running by the time this runs (it boots with the
+// node container's init), so the config change wouldn't otherwise be
+// picked up before kubelet starts.
+func writeRegistryMirrors(nodeList []nodes.Node, mirrors map[string]string) error {
+	for _, n := range nodeList {
+		for name, url := range mirrors {
+			entry := fmt.Sprintf(
+				"\n[plugins.\"io.containerd.grpc.v1.cri\".registry.mirrors.%q]\n  endpoint = [%q]\n",
+				name, url,
+			)
+			cmd := fmt.Sprintf("cat >> /etc/containerd/config.toml <<'EOF'\n%s\nEOF", entry)
+			if err := docker.Exec(n.Name, "sh", "-c", cmd); err != nil {
+				return errors.Wrapf(err, "failed to write registry mirror %q on node %q", name, n.Name)
+			}
+		}
+		if err := docker.Exec(n.Name, "systemctl", "restart", "containerd"); err != nil {
+			return errors.Wrapf(err, "failed to restart containerd on node %q", n.Name)
+		}
+	}
+	return nil
+}
+
+// recordRegistryConfigMap records endpoint as the registry's advertised
+// address in the well-known local-registry-hosting ConfigMap in
+// kube-public, via `kubectl apply -f -` from a control-plane node, so
+// downstream tests can discover it without knowing kinder's internal naming.
+func recordRegistryConfigMap(controlPlaneNode, endpoint string) error {
+	manifest := fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: kube-public
+data:
+  localRegistryHosting.v1: |
+    host: "%s"
+    help: "https://kind.sigs.k8s.io/docs/user/local-registry/"
+`, registryConfigMapName, endpoint)
+
+	cmd := fmt.Sprintf("cat <<'EOF' | kubectl --kubeconfig=/etc/kubernetes/admin.conf apply -f -\n%s\nEOF", manifest)
+	if err := docker.Exec(controlPlaneNode, "sh", "-c", cmd); err != nil {
+		return errors.Wrapf(err, "failed to record registry ConfigMap on node %q", controlPlaneNode)
+	}
+	return nil
+}
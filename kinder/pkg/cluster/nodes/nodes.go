@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodes provides the node provider kinder uses to discover and
+// drive the docker containers backing a cluster's nodes.
+package nodes
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/kubeadm/kinder/pkg/constants"
+	"k8s.io/kubeadm/kinder/pkg/container/docker"
+)
+
+// Node is a single docker container acting as a kinder cluster node.
+type Node struct {
+	// Name is the docker container name.
+	Name string
+	// Role is the node's role label (control-plane, worker, external-etcd, external-load-balancer).
+	Role string
+}
+
+// List returns every node belonging to clusterName, in the order docker
+// reports them, with their Role populated from the node's docker labels.
+func List(clusterName string) ([]Node, error) {
+	names, err := docker.ListByLabel(fmt.Sprintf("%s=%s", constants.ClusterLabelKey, clusterName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list nodes for cluster %q", clusterName)
+	}
+
+	nodeList := make([]Node, 0, len(names))
+	for _, name := range names {
+		role, err := docker.Label(name, constants.NodeRoleLabelKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read role for node %q", name)
+		}
+		nodeList = append(nodeList, Node{Name: name, Role: role})
+	}
+	return nodeList, nil
+}
+
+// StopNodes stops all the given nodes with a single `docker stop` call, so a
+// many-node cluster is hibernated in one exec instead of one-per-node.
+func StopNodes(nodeList ...Node) error {
+	return docker.Stop(names(nodeList)...)
+}
+
+// StartNodes starts all the given nodes with a single `docker start` call.
+func StartNodes(nodeList ...Node) error {
+	return docker.Start(names(nodeList)...)
+}
+
+// RemoveNodes force-removes all the given nodes with a single
+// `docker rm -f` call, deleting their containers and disk state entirely.
+func RemoveNodes(nodeList ...Node) error {
+	return docker.Remove(names(nodeList)...)
+}
+
+func names(nodeList []Node) []string {
+	out := make([]string, 0, len(nodeList))
+	for _, n := range nodeList {
+		out = append(out, n.Name)
+	}
+	return out
+}
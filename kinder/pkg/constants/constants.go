@@ -0,0 +1,43 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package constants holds values shared across kinder's commands and packages.
+package constants
+
+const (
+	// DefaultClusterName is the default cluster name used by kinder.
+	DefaultClusterName = "kind"
+
+	// ClusterLabelKey is the docker label kinder attaches to every container
+	// belonging to a cluster, so groups of containers can be discovered and
+	// driven together (e.g. stopped/started as a batch).
+	ClusterLabelKey = "io.k8s.sigs.kind.cluster"
+
+	// NodeRoleLabelKey is the docker label kinder attaches to a container
+	// recording its node role (control-plane, worker, external-etcd, ...).
+	NodeRoleLabelKey = "io.k8s.sigs.kind.role"
+
+	// ControlPlaneNodeRoleValue is the NodeRoleLabelKey value for a
+	// control-plane node.
+	ControlPlaneNodeRoleValue = "control-plane"
+
+	// WorkerNodeRoleValue is the NodeRoleLabelKey value for a worker node.
+	WorkerNodeRoleValue = "worker"
+
+	// ExternalLoadBalancerNodeRoleValue is the NodeRoleLabelKey value for the
+	// external load balancer node put in front of the control-plane nodes.
+	ExternalLoadBalancerNodeRoleValue = "external-load-balancer"
+)
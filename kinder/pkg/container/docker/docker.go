@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package docker wraps the subset of the docker CLI kinder shells out to in
+// order to drive cluster node containers.
+package docker
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ListByLabel returns the names of all containers matching the given
+// "key=value" docker label filter, regardless of their current state.
+func ListByLabel(label string) ([]string, error) {
+	cmd := exec.Command("docker", "ps", "-a",
+		"--filter", fmt.Sprintf("label=%s", label),
+		"--format", "{{.Names}}",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list containers for label %q: %s", label, out)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	names := make([]string, 0, len(lines))
+	for _, l := range lines {
+		if l != "" {
+			names = append(names, l)
+		}
+	}
+	return names, nil
+}
+
+// Stop stops the given containers with a single `docker stop` invocation.
+func Stop(containerNames ...string) error {
+	if len(containerNames) == 0 {
+		return nil
+	}
+	args := append([]string{"stop"}, containerNames...)
+	if out, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to stop containers %v: %s", containerNames, out)
+	}
+	return nil
+}
+
+// Start starts the given containers with a single `docker start` invocation.
+func Start(containerNames ...string) error {
+	if len(containerNames) == 0 {
+		return nil
+	}
+	args := append([]string{"start"}, containerNames...)
+	if out, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to start containers %v: %s", containerNames, out)
+	}
+	return nil
+}
+
+// Remove force-removes the given containers with a single `docker rm -f`
+// invocation, deleting them (and their disk state) even if still running.
+func Remove(containerNames ...string) error {
+	if len(containerNames) == 0 {
+		return nil
+	}
+	args := append([]string{"rm", "-f"}, containerNames...)
+	if out, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to remove containers %v: %s", containerNames, out)
+	}
+	return nil
+}
+
+// CopyTo copies the file or directory at src on the host into destPath
+// inside containerName, via `docker cp`.
+func CopyTo(containerName, src, destPath string) error {
+	dest := fmt.Sprintf("%s:%s", containerName, destPath)
+	if out, err := exec.Command("docker", "cp", src, dest).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to copy %q to %q: %s", src, dest, out)
+	}
+	return nil
+}
+
+// Save writes a `docker save` tarball for image to destPath.
+func Save(image, destPath string) error {
+	if out, err := exec.Command("docker", "save", "-o", destPath, image).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to save image %q: %s", image, out)
+	}
+	return nil
+}
+
+// Exec runs the given command inside containerName via `docker exec`.
+func Exec(containerName string, command ...string) error {
+	args := append([]string{"exec", containerName}, command...)
+	if out, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to exec %v in container %q: %s", command, containerName, out)
+	}
+	return nil
+}
+
+// Output runs the given command inside containerName via `docker exec` and
+// returns its stdout only, so callers that feed the result back into
+// another command (e.g. a generated join command) aren't corrupted by
+// informational output the command logs to stderr.
+func Output(containerName string, command ...string) (string, error) {
+	args := append([]string{"exec", containerName}, command...)
+	cmd := exec.Command("docker", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		stderr := ""
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr = string(exitErr.Stderr)
+		}
+		return "", errors.Wrapf(err, "failed to exec %v in container %q: %s", command, containerName, stderr)
+	}
+	return string(out), nil
+}
+
+// Label returns the value of the docker label key on containerName, via
+// `docker inspect`.
+func Label(containerName, key string) (string, error) {
+	out, err := exec.Command("docker", "inspect", containerName,
+		"--format", fmt.Sprintf("{{ index .Config.Labels %q }}", key),
+	).CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to inspect container %q: %s", containerName, out)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Exists reports whether a container named containerName exists, in any state.
+func Exists(containerName string) (bool, error) {
+	out, err := exec.Command("docker", "ps", "-a",
+		"--filter", fmt.Sprintf("name=^%s$", containerName),
+		"--format", "{{.Names}}",
+	).CombinedOutput()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to check for container %q: %s", containerName, out)
+	}
+	return strings.TrimSpace(string(out)) != "", nil
+}
+
+// Run starts a new detached container named containerName from image, via
+// `docker run -d --name ...`, with extraArgs passed through to docker run
+// before the image name.
+func Run(containerName, image string, extraArgs ...string) error {
+	args := append([]string{"run", "-d", "--name", containerName}, extraArgs...)
+	args = append(args, image)
+	if out, err := exec.Command("docker", args...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to run container %q: %s", containerName, out)
+	}
+	return nil
+}
+
+// Connect attaches an already-running container to the given docker network.
+func Connect(network, containerName string) error {
+	if out, err := exec.Command("docker", "network", "connect", network, containerName).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to connect %q to network %q: %s", containerName, network, out)
+	}
+	return nil
+}